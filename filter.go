@@ -0,0 +1,185 @@
+// Copyright (c) Tetrate, Inc 2021.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-kit/log"
+	"github.com/tetratelabs/telemetry/level"
+)
+
+// stringToLevel maps the "level" value Logger.Debug/Info/Error attach to
+// each log line back to its level.Value, so a filter can compare it against
+// a configured threshold.
+var stringToLevel = map[string]level.Value{
+	"debug": level.Debug,
+	"info":  level.Info,
+	"error": level.Error,
+}
+
+// FilterOption configures a Logger returned by NewFilter.
+type FilterOption func(*filter)
+
+// AllowLevel sets the global logging threshold of a Filter, same as
+// Logger.SetLevel, but without mutating the Logger the Filter wraps.
+func AllowLevel(lvl level.Value) FilterOption {
+	return func(f *filter) {
+		f.SetLevel(lvl)
+	}
+}
+
+// AllowAll allows every log line through the Filter.
+func AllowAll() FilterOption {
+	return AllowLevel(level.Debug)
+}
+
+// AllowNone suppresses every log line at the Filter.
+func AllowNone() FilterOption {
+	return AllowLevel(level.None)
+}
+
+// AllowByKey raises or lowers the logging threshold whenever key is present
+// among a log line's key-value pairs (attached via With(), the Context, or
+// as call arguments) and its value matches an entry in valueToLevel. The
+// per-key threshold is checked in preference to the global threshold set by
+// AllowLevel/AllowAll/AllowNone, e.g.
+//
+//	AllowByKey("module", map[string]level.Value{"p2p": level.Debug, "consensus": level.Error})
+//
+// turns on debug logging for the "p2p" module and silences all but errors
+// for "consensus", while every other module keeps using the global
+// threshold.
+func AllowByKey(key string, valueToLevel map[string]level.Value) FilterOption {
+	return func(f *filter) {
+		m := make(map[string]level.Value, len(valueToLevel))
+		for k, v := range valueToLevel {
+			m[k] = v
+		}
+		f.mtx.Lock()
+		f.byKey[key] = m
+		f.mtx.Unlock()
+	}
+}
+
+// filter is a Go kit log.Logger that applies a global threshold and optional
+// per-key thresholds before forwarding log lines to next, modeled after Go
+// kit's own level.NewFilter.
+type filter struct {
+	next log.Logger
+
+	lvl int32 // atomic, guarded via atomic.Load/StoreInt32
+
+	mtx   sync.RWMutex
+	byKey map[string]map[string]level.Value
+}
+
+// SetLevel sets the Filter's global logging threshold, equivalent to
+// AllowLevel but usable at runtime -- this is what lets Manager adjust a
+// filter-backed scope's verbosity via SetScopeOutputLevel/
+// SetDefaultOutputLevel without defeating AllowByKey (see targetLevel).
+func (f *filter) SetLevel(lvl level.Value) {
+	atomic.StoreInt32(&f.lvl, int32(lvl))
+}
+
+// Level returns the Filter's current global logging threshold.
+func (f *filter) Level() level.Value {
+	return level.Value(atomic.LoadInt32(&f.lvl))
+}
+
+// Log implements log.Logger. It never invokes next when the computed
+// threshold suppresses the log line.
+func (f *filter) Log(keyvals ...interface{}) error {
+	lvl, ok := level.None, false
+	threshold := level.Value(atomic.LoadInt32(&f.lvl))
+
+	f.mtx.RLock()
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		k, v := keyvals[i], keyvals[i+1]
+		if k == "level" {
+			if s, isString := v.(string); isString {
+				lvl, ok = stringToLevel[s]
+			}
+			continue
+		}
+		key, isString := k.(string)
+		if !isString {
+			continue
+		}
+		valueToLevel, tracked := f.byKey[key]
+		if !tracked {
+			continue
+		}
+		if s, isString := v.(string); isString {
+			if override, has := valueToLevel[s]; has {
+				threshold = override
+			}
+		}
+	}
+	f.mtx.RUnlock()
+
+	if !ok || lvl > threshold {
+		return nil
+	}
+	return f.next.Log(keyvals...)
+}
+
+// NewFilter returns a Logger that wraps next and applies opts to decide,
+// per log line, whether it should reach next's underlying Go kit logger.
+// The returned Logger is independent of next: adjusting its thresholds
+// never changes the level at which next itself logs.
+func NewFilter(next *Logger, opts ...FilterOption) *Logger {
+	f := &filter{
+		next:  next.UnwrapLogger(),
+		lvl:   int32(level.Info),
+		byKey: make(map[string]map[string]level.Value),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	newLogger := New(f)
+	// Every log line must reach f.Log so that AllowByKey can raise verbosity
+	// above the global threshold for a tracked key; f.Log makes the real
+	// decision and already skips invoking next when a line is suppressed.
+	newLogger.SetLevel(level.Debug)
+	newLogger.ctx = next.ctx
+	newLogger.metric = next.metric
+	newLogger.args = make([]interface{}, len(next.args))
+	copy(newLogger.args, next.args)
+
+	return newLogger
+}
+
+// levelSetter is the subset of Logger's API needed to adjust a global
+// logging threshold at runtime. Both Logger and filter implement it.
+type levelSetter interface {
+	SetLevel(level.Value)
+	Level() level.Value
+}
+
+// targetLevel returns the levelSetter that actually governs l's verbosity.
+// For a plain Logger that is l itself; for a Logger returned by NewFilter,
+// l's own threshold is pinned to level.Debug so every line reaches the
+// filter, so the filter's independent threshold must be adjusted instead --
+// otherwise a runtime SetLevel call (e.g. via Manager.SetScopeOutputLevel)
+// would clobber the pinned gate and silently defeat AllowByKey.
+func targetLevel(l *Logger) levelSetter {
+	if f, ok := l.UnwrapLogger().(*filter); ok {
+		return f
+	}
+	return l
+}
@@ -0,0 +1,151 @@
+// Copyright (c) Tetrate, Inc 2021.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	log "github.com/tetratelabs/telemetry-gokit-log"
+	"github.com/tetratelabs/telemetry/level"
+)
+
+func TestFilterAllowLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	base := log.NewSyncLogfmt(buf)
+	filtered := log.NewFilter(base, log.AllowLevel(level.Error))
+
+	filtered.Info("hello")
+	if want, have := "", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+
+	buf.Reset()
+	filtered.Error("boom", errors.New("bang"))
+	if want, have := "msg=boom level=error error=bang\n", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+
+	// the wrapped Logger is untouched: it still logs at its own level.
+	buf.Reset()
+	base.Info("hello")
+	if want, have := "msg=hello level=info\n", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+}
+
+func TestFilterAllowAllAllowNone(t *testing.T) {
+	buf := &bytes.Buffer{}
+	base := log.NewSyncLogfmt(buf)
+
+	all := log.NewFilter(base, log.AllowAll())
+	all.Debug("hello")
+	if want, have := "msg=hello level=debug\n", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+
+	buf.Reset()
+	none := log.NewFilter(base, log.AllowNone())
+	none.Error("boom", nil)
+	if want, have := "", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+}
+
+func TestFilterAllowByKey(t *testing.T) {
+	buf := &bytes.Buffer{}
+	base := log.NewSyncLogfmt(buf)
+	filtered := log.NewFilter(base,
+		log.AllowLevel(level.Info),
+		log.AllowByKey("module", map[string]level.Value{
+			"p2p":       level.Debug,
+			"consensus": level.Error,
+		}),
+	)
+
+	p2p := filtered.With("module", "p2p")
+	buf.Reset()
+	p2p.Debug("hello")
+	if want, have := "msg=hello level=debug module=p2p\n", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+
+	consensus := filtered.With("module", "consensus")
+	buf.Reset()
+	consensus.Info("hello")
+	if want, have := "", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+
+	other := filtered.With("module", "mempool")
+	buf.Reset()
+	other.Debug("hello")
+	if want, have := "", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+	buf.Reset()
+	other.Info("hello")
+	if want, have := "msg=hello level=info module=mempool\n", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+}
+
+func TestManagerRegisterScopeWithFilter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	manager := log.NewManager(log.NewSyncLogfmt(buf))
+
+	scoped := manager.RegisterScope("p2p", "peer to peer networking",
+		log.AllowLevel(log.Error),
+		log.AllowByKey("module", map[string]level.Value{"p2p": level.Debug}),
+	).With("module", "p2p")
+
+	buf.Reset()
+	scoped.Debug("hello")
+	if want, have := "msg=hello level=debug module=p2p\n", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+}
+
+func TestManagerSetScopeOutputLevelDoesNotDefeatAllowByKey(t *testing.T) {
+	buf := &bytes.Buffer{}
+	manager := log.NewManager(log.NewSyncLogfmt(buf))
+
+	scoped := manager.RegisterScope("p2p", "peer to peer networking",
+		log.AllowLevel(log.Error),
+		log.AllowByKey("module", map[string]level.Value{"p2p": level.Debug}),
+	).With("module", "p2p")
+
+	// SetScopeOutputLevel is exactly what the --log-output-level flag and the
+	// HTTP admin endpoint use to adjust verbosity at runtime; it must not
+	// clobber the filter's own threshold and silently defeat AllowByKey.
+	if err := manager.SetScopeOutputLevel("p2p", log.Info); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf.Reset()
+	scoped.Debug("hello")
+	if want, have := "msg=hello level=debug module=p2p\n", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+
+	lvl, err := manager.GetOutputLevel("p2p")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want, have := log.Info, lvl; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
@@ -18,6 +18,7 @@ package group
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/tetratelabs/multierror"
@@ -29,11 +30,13 @@ import (
 // Exported flags.
 const (
 	LogOutputLevel = "log-output-level"
+	LogFormat      = "log-format"
 )
 
 // Default configuration values.
 const (
 	DefaultLogOutputLevel = "info"
+	DefaultLogFormat      = "logfmt"
 )
 
 var stringToLevel = map[string]log.Level{
@@ -43,15 +46,38 @@ var stringToLevel = map[string]log.Level{
 	"debug": log.Debug,
 }
 
+var stringToFormat = map[string]log.LogFormat{
+	"logfmt": log.FormatLogfmt,
+	"json":   log.FormatJSON,
+}
+
 type service struct {
 	outputLevels string
+	outputFormat string
 	manager      *log.Manager
+	w            io.Writer
+}
+
+// Option configures the service returned by New.
+type Option func(*service)
+
+// WithOutputWriter enables the --log-format flag, reconfiguring the wire
+// format of manager's default and scoped loggers to write to w whenever a
+// supported format is requested. Without this option, --log-format is not
+// registered and the loggers keep whatever format they were constructed
+// with.
+func WithOutputWriter(w io.Writer) Option {
+	return func(s *service) { s.w = w }
 }
 
 // New returns a new run Group Config to manage configuration of our scoped
 // logger.
-func New(manager *log.Manager) run.Config {
-	return &service{manager: manager}
+func New(manager *log.Manager, opts ...Option) run.Config {
+	s := &service{manager: manager}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Name implements run.Unit.
@@ -74,6 +100,14 @@ func (s *service) FlagSet() *run.FlagSet {
 		"debug", "info", "error",
 	))
 
+	if s.w != nil {
+		if s.outputFormat == "" {
+			s.outputFormat = DefaultLogFormat
+		}
+		fs.StringVar(&s.outputFormat, LogFormat, s.outputFormat,
+			"Log output format, one of [logfmt, json]")
+	}
+
 	return fs
 }
 
@@ -110,5 +144,14 @@ func (s *service) Validate() error {
 		}
 	}
 
+	if s.w != nil {
+		format, ok := stringToFormat[strings.ToLower(strings.Trim(s.outputFormat, "\r\n\t "))]
+		if !ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("%q is not a valid log format", s.outputFormat))
+		} else {
+			s.manager.SetOutputFormat(format, s.w)
+		}
+	}
+
 	return mErr
 }
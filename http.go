@@ -0,0 +1,132 @@
+// Copyright (c) Tetrate, Inc 2021.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// scopeInfo is the JSON representation of a single registered scope, as
+// returned by the Manager admin API.
+type scopeInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Level       string `json:"level"`
+}
+
+// levelRequest is the JSON body accepted by PUT requests against the
+// Manager admin API.
+type levelRequest struct {
+	Level string `json:"level"`
+}
+
+// Handler returns an http.Handler exposing a small JSON admin API to inspect
+// and adjust the output level of registered scopes on a running service,
+// without a restart or config reload:
+//
+//	GET  /scopes             list all registered scopes, including "default"
+//	GET  /scopes?format=text the same information as PrintRegisteredScopes
+//	PUT  /scopes             {"level":"debug"} sets the default output level
+//	PUT  /scopes/{name}      {"level":"debug"} sets a single scope's level
+func (s *Manager) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/scopes", s.handleScopes)
+	mux.HandleFunc("/scopes/", s.handleScope)
+	return mux
+}
+
+func (s *Manager) handleScopes(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if r.URL.Query().Get("format") == "text" {
+			_ = s.writeRegisteredScopes(w)
+			return
+		}
+		s.writeScopesJSON(w)
+	case http.MethodPut:
+		lvl, ok := s.decodeLevel(w, r)
+		if !ok {
+			return
+		}
+		s.SetDefaultOutputLevel(lvl)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Manager) handleScope(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/scopes/")
+	if name == "" {
+		s.handleScopes(w, r)
+		return
+	}
+	if r.Method != http.MethodPut {
+		w.Header().Set("Allow", "PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	lvl, ok := s.decodeLevel(w, r)
+	if !ok {
+		return
+	}
+	if err := s.SetScopeOutputLevel(name, lvl); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeLevel decodes and validates a levelRequest from r's body, writing an
+// error response and returning ok == false if it is malformed or names an
+// unknown level.
+func (s *Manager) decodeLevel(w http.ResponseWriter, r *http.Request) (lvl Level, ok bool) {
+	var req levelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("malformed request body: %v", err), http.StatusBadRequest)
+		return None, false
+	}
+	lvl, ok = stringToLevel[strings.ToLower(strings.TrimSpace(req.Level))]
+	if !ok {
+		http.Error(w, fmt.Sprintf("%q is not a valid log level", req.Level), http.StatusBadRequest)
+		return None, false
+	}
+	return lvl, true
+}
+
+func (s *Manager) writeScopesJSON(w http.ResponseWriter) {
+	s.mtx.Lock()
+	infos := make([]scopeInfo, 0, len(s.registry)+1)
+	for _, sc := range s.registry {
+		infos = append(infos, scopeInfo{
+			Name:        sc.name,
+			Description: sc.description,
+			Level:       levelToString[targetLevel(sc.logger).Level()],
+		})
+	}
+	defaultLevel := levelToString[s.logger.Level()]
+	s.mtx.Unlock()
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	infos = append([]scopeInfo{{Name: "default", Level: defaultLevel}}, infos...)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(infos)
+}
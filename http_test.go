@@ -0,0 +1,203 @@
+// Copyright (c) Tetrate, Inc 2021.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	log "github.com/tetratelabs/telemetry-gokit-log"
+)
+
+func newTestManager() *log.Manager {
+	return log.NewManager(log.NewSyncLogfmt(&bytes.Buffer{}))
+}
+
+func TestHandlerGetScopes(t *testing.T) {
+	manager := newTestManager()
+	manager.RegisterScope("p2p", "peer to peer networking")
+	handler := manager.Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/scopes", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want status %d, have %d", http.StatusOK, rec.Code)
+	}
+
+	var got []map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want, have := 2, len(got); want != have {
+		t.Fatalf("want %d scopes, have %d", want, have)
+	}
+	if want, have := "default", got[0]["name"]; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := "p2p", got[1]["name"]; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestHandlerGetScopesText(t *testing.T) {
+	manager := newTestManager()
+	manager.RegisterScope("p2p", "peer to peer networking")
+	handler := manager.Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/scopes?format=text", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want status %d, have %d", http.StatusOK, rec.Code)
+	}
+	if want, have := "registered logging scopes:", strings.Split(rec.Body.String(), "\n")[0]; want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestHandlerPutDefault(t *testing.T) {
+	manager := newTestManager()
+	handler := manager.Handler()
+
+	req := httptest.NewRequest(http.MethodPut, "/scopes", strings.NewReader(`{"level":"debug"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("want status %d, have %d", http.StatusNoContent, rec.Code)
+	}
+	if want, have := log.Debug, manager.GetDefaultOutputLevel(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestHandlerPutScope(t *testing.T) {
+	manager := newTestManager()
+	manager.RegisterScope("p2p", "peer to peer networking")
+	handler := manager.Handler()
+
+	// case and whitespace are normalized consistent with RegisterScope.
+	req := httptest.NewRequest(http.MethodPut, "/scopes/%20P2P%20", strings.NewReader(`{"level":"Debug"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("want status %d, have %d", http.StatusNoContent, rec.Code)
+	}
+	lvl, err := manager.GetOutputLevel("p2p")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want, have := log.Debug, lvl; want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+}
+
+func TestHandlerPutUnknownScope(t *testing.T) {
+	manager := newTestManager()
+	handler := manager.Handler()
+
+	req := httptest.NewRequest(http.MethodPut, "/scopes/does-not-exist", strings.NewReader(`{"level":"debug"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("want status %d, have %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestHandlerMalformedBody(t *testing.T) {
+	manager := newTestManager()
+	manager.RegisterScope("p2p", "peer to peer networking")
+	handler := manager.Handler()
+
+	for name, body := range map[string]string{
+		"not json":      "{",
+		"unknown level": `{"level":"verbose"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/scopes/p2p", strings.NewReader(body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("want status %d, have %d", http.StatusBadRequest, rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandlerConcurrentRegisterAndUpdate(t *testing.T) {
+	manager := newTestManager()
+	handler := manager.Handler()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			manager.RegisterScope(fmt.Sprintf("scope-%d", i), "")
+		}(i)
+	}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPut, "/scopes", strings.NewReader(`{"level":"debug"}`))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != http.StatusNoContent {
+				t.Errorf("want status %d, have %d", http.StatusNoContent, rec.Code)
+			}
+
+			rec = httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/scopes", nil))
+			if rec.Code != http.StatusOK {
+				t.Errorf("want status %d, have %d", http.StatusOK, rec.Code)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestHandlerConcurrentUpdates(t *testing.T) {
+	manager := newTestManager()
+	manager.RegisterScope("p2p", "peer to peer networking")
+	handler := manager.Handler()
+
+	levels := []string{"debug", "info", "error"}
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			body := fmt.Sprintf(`{"level":%q}`, levels[i%len(levels)])
+			req := httptest.NewRequest(http.MethodPut, "/scopes/p2p", strings.NewReader(body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != http.StatusNoContent {
+				t.Errorf("want status %d, have %d", http.StatusNoContent, rec.Code)
+			}
+
+			rec = httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/scopes", nil))
+			if rec.Code != http.StatusOK {
+				t.Errorf("want status %d, have %d", http.StatusOK, rec.Code)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
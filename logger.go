@@ -18,6 +18,7 @@ package logger
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"sync/atomic"
 
@@ -71,12 +72,63 @@ func NewSyncLogfmt(w io.Writer) *Logger {
 	return New(log.NewSyncLogger(log.NewLogfmtLogger(w)))
 }
 
+// NewSyncJSON returns a new telemetry.Logger implementation using Go kit's
+// sync writer and JSON output format.
+func NewSyncJSON(w io.Writer) *Logger {
+	return New(log.NewSyncLogger(log.NewJSONLogger(w)))
+}
+
+// LogFormat identifies the wire format a Logger renders its output in.
+type LogFormat int
+
+// Supported LogFormat values.
+const (
+	FormatLogfmt LogFormat = iota
+	FormatJSON
+)
+
+// MustNewDefaultLogger returns a new Logger writing to w in the requested
+// format, with lvl as its initial logging level. It panics if format is not
+// one of the supported LogFormat values, so it is only safe to use with a
+// format derived from a closed set, e.g. a validated --log-format flag.
+func MustNewDefaultLogger(format LogFormat, lvl level.Value, w io.Writer) *Logger {
+	var l *Logger
+	switch format {
+	case FormatJSON:
+		l = NewSyncJSON(w)
+	case FormatLogfmt:
+		l = NewSyncLogfmt(w)
+	default:
+		panic(fmt.Sprintf("logger: unsupported log format %d", format))
+	}
+	l.SetLevel(lvl)
+	return l
+}
+
 // UnwrapLogger returns the wrapped original logger implementation used by this
 // Logging bridge.
 func (l *Logger) UnwrapLogger() log.Logger {
 	return l.logger
 }
 
+// ctxLogger is implemented by Go kit log.Logger backends that need the
+// Logger's attached Context to produce a log line, e.g. to correlate with an
+// active trace span. Backends that have no use for it just implement
+// log.Logger as usual.
+type ctxLogger interface {
+	LogContext(ctx context.Context, keyvals ...interface{}) error
+}
+
+// log forwards args to the wrapped Go kit logger, giving it access to
+// l.ctx when it implements ctxLogger.
+func (l *Logger) log(args []interface{}) {
+	if cl, ok := l.logger.(ctxLogger); ok {
+		_ = cl.LogContext(l.ctx, args...)
+		return
+	}
+	_ = l.logger.Log(args...)
+}
+
 // Debug logging with key-value pairs. Don't be shy, use it.
 func (l *Logger) Debug(msg string, keyValues ...interface{}) {
 	if atomic.LoadInt32(l.lvl) < int32(Debug) {
@@ -86,7 +138,7 @@ func (l *Logger) Debug(msg string, keyValues ...interface{}) {
 	args = append(args, telemetry.KeyValuesFromContext(l.ctx)...)
 	args = append(args, l.args...)
 	args = append(args, keyValues...)
-	_ = l.logger.Log(args...)
+	l.log(args)
 }
 
 // Info logging with key-value pairs. This is for informational, but not
@@ -108,7 +160,7 @@ func (l *Logger) Info(msg string, keyValues ...interface{}) {
 	args = append(args, telemetry.KeyValuesFromContext(l.ctx)...)
 	args = append(args, l.args...)
 	args = append(args, keyValues...)
-	_ = l.logger.Log(args...)
+	l.log(args)
 }
 
 // Error logging with key-value pairs. Use this when application state and
@@ -128,7 +180,7 @@ func (l *Logger) Error(msg string, err error, keyValues ...interface{}) {
 	args = append(args, telemetry.KeyValuesFromContext(l.ctx)...)
 	args = append(args, l.args...)
 	args = append(args, keyValues...)
-	_ = l.logger.Log(args...)
+	l.log(args)
 }
 
 // With returns Logger with provided key value pairs attached.
@@ -22,6 +22,7 @@ import (
 
 	"github.com/tetratelabs/telemetry"
 	log "github.com/tetratelabs/telemetry-gokit-log"
+	"github.com/tetratelabs/telemetry/level"
 )
 
 func TestSyncLogfmt(t *testing.T) {
@@ -37,9 +38,9 @@ func TestSyncLogfmt(t *testing.T) {
 		t.Errorf("want %#v, have %#v", want, have)
 	}
 
-	logger.SetLevel(telemetry.LevelDebug)
-	if logger.Level() != telemetry.LevelDebug {
-		t.Errorf("want %v, have %v", logger.Level(), telemetry.LevelDebug)
+	logger.SetLevel(level.Debug)
+	if logger.Level() != level.Debug {
+		t.Errorf("want %v, have %v", level.Debug, logger.Level())
 	}
 	buf.Reset()
 	logger.Debug("hello")
@@ -90,10 +91,75 @@ func TestSyncLogfmt(t *testing.T) {
 	}
 
 	o := logger.New()
-	o.SetLevel(telemetry.LevelError)
+	o.SetLevel(level.Error)
 	buf.Reset()
 	o.Debug("silence")
 	if want, have := "", buf.String(); want != have {
 		t.Errorf("want %#v, have %#v", want, have)
 	}
 }
+
+func TestSyncJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := log.NewSyncJSON(buf)
+	logger.Info("hello")
+	if want, have := `{"level":"info","msg":"hello"}`+"\n", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+	buf.Reset()
+	logger.Debug("hello")
+	if want, have := "", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+
+	logger.SetLevel(level.Debug)
+	buf.Reset()
+	logger.Debug("ok", "a", 1, "err", errors.New("error"))
+	if want, have := `{"a":1,"err":"error","level":"debug","msg":"ok"}`+"\n", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+
+	// JSON output is rendered from a map, so the key order on the wire is
+	// always alphabetical regardless of the order keys were added through
+	// With() -- unlike logfmt, which preserves insertion order.
+	l := logger.With("zzz", 1).With("aaa", 2)
+	buf.Reset()
+	l.Error("error", errors.New("error"))
+	if want, have := `{"aaa":2,"error":"error","level":"error","msg":"error","zzz":1}`+"\n", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+
+	ctx := telemetry.KeyValuesToContext(context.Background(), "ctx", "val")
+	buf.Reset()
+	l.Context(ctx).Error("error", errors.New("error"))
+	if want, have := `{"aaa":2,"ctx":"val","error":"error","level":"error","msg":"error","zzz":1}`+"\n", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+}
+
+func TestMustNewDefaultLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := log.MustNewDefaultLogger(log.FormatJSON, level.Debug, buf)
+	logger.Debug("hello")
+	if want, have := `{"level":"debug","msg":"hello"}`+"\n", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+
+	buf.Reset()
+	logger = log.MustNewDefaultLogger(log.FormatLogfmt, level.Error, buf)
+	logger.Info("silence")
+	if want, have := "", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+	logger.Error("boom", errors.New("bang"))
+	if want, have := "msg=boom level=error error=bang\n", buf.String(); want != have {
+		t.Errorf("want %#v, have %#v", want, have)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for unsupported log format")
+		}
+	}()
+	log.MustNewDefaultLogger(log.LogFormat(99), level.Info, buf)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) Tetrate, Inc 2021.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// defaultOTLPScopeName is the instrumentation scope name the OTLPLogger
+// reports itself under unless overridden by WithScopeName.
+const defaultOTLPScopeName = "github.com/tetratelabs/telemetry-gokit-log"
+
+// OTLPOption configures a Logger created by NewOTLPLogger.
+type OTLPOption func(*otlpConfig)
+
+type otlpConfig struct {
+	scopeName string
+}
+
+// WithScopeName overrides the instrumentation scope name the OTLPLogger
+// reports itself under. Defaults to this module's import path.
+func WithScopeName(name string) OTLPOption {
+	return func(c *otlpConfig) { c.scopeName = name }
+}
+
+// NewOTLPLogger returns a telemetry.Logger implementation that emits
+// structured records through the OpenTelemetry Logs SDK, routed to exporter,
+// instead of through Go kit. Records are exported synchronously via a
+// SimpleProcessor, matching NewSyncLogfmt/NewSyncJSON's deterministic,
+// one-line-per-call behavior. It keeps the regular Logger's With/Context/
+// Metric/SetLevel behavior, including the level short-circuit that skips
+// record construction entirely for suppressed log lines.
+func NewOTLPLogger(exporter sdklog.Exporter, opts ...OTLPOption) *Logger {
+	cfg := otlpConfig{scopeName: defaultOTLPScopeName}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	return New(&otlpAdapter{logger: provider.Logger(cfg.scopeName)})
+}
+
+// otlpAdapter bridges Go kit's log.Logger interface -- and the richer
+// ctxLogger interface Logger prefers when available -- to an OpenTelemetry
+// Logs SDK Logger.
+type otlpAdapter struct {
+	logger otellog.Logger
+}
+
+// Log implements log.Logger, for callers that reach the adapter without a
+// Context, e.g. via UnwrapLogger().
+func (a *otlpAdapter) Log(keyvals ...interface{}) error {
+	return a.LogContext(context.Background(), keyvals...)
+}
+
+// LogContext implements ctxLogger. It translates the msg/level/error/KV
+// pairs Logger.Debug/Info/Error produce into an OpenTelemetry log Record,
+// and, if ctx carries an active span, attaches that span's trace and span
+// IDs so the record correlates with it.
+func (a *otlpAdapter) LogContext(ctx context.Context, keyvals ...interface{}) error {
+	var rec otellog.Record
+	rec.SetTimestamp(time.Now())
+	rec.SetObservedTimestamp(time.Now())
+
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, "(MISSING)")
+	}
+	for i := 0; i < len(keyvals); i += 2 {
+		k, ok := keyvals[i].(string)
+		if !ok {
+			continue
+		}
+		v := keyvals[i+1]
+		switch k {
+		case "msg":
+			if s, ok := v.(string); ok {
+				rec.SetBody(otellog.StringValue(s))
+			}
+		case "level":
+			if s, ok := v.(string); ok {
+				rec.SetSeverityText(s)
+				rec.SetSeverity(severityFromString(s))
+			}
+		case "error":
+			if err, ok := v.(error); ok && err != nil {
+				rec.AddAttributes(
+					otellog.String("exception.type", fmt.Sprintf("%T", err)),
+					otellog.String("exception.message", err.Error()),
+				)
+			}
+		default:
+			rec.AddAttributes(otellog.KeyValue{Key: k, Value: toLogValue(v)})
+		}
+	}
+
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		rec.AddAttributes(
+			otellog.String("trace_id", span.TraceID().String()),
+			otellog.String("span_id", span.SpanID().String()),
+		)
+	}
+
+	a.logger.Emit(ctx, rec)
+	return nil
+}
+
+// severityFromString maps the level string Logger attaches to each log line
+// to the closest OpenTelemetry Severity.
+func severityFromString(s string) otellog.Severity {
+	switch s {
+	case "debug":
+		return otellog.SeverityDebug
+	case "error":
+		return otellog.SeverityError
+	default:
+		return otellog.SeverityInfo
+	}
+}
+
+// toLogValue converts a logging key's value to an OpenTelemetry log Value,
+// falling back to its string representation for types the Logs API has no
+// native representation for.
+func toLogValue(v interface{}) otellog.Value {
+	switch val := v.(type) {
+	case string:
+		return otellog.StringValue(val)
+	case bool:
+		return otellog.BoolValue(val)
+	case int:
+		return otellog.Int64Value(int64(val))
+	case int64:
+		return otellog.Int64Value(val)
+	case float64:
+		return otellog.Float64Value(val)
+	default:
+		return otellog.StringValue(fmt.Sprintf("%v", val))
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Tetrate, Inc 2021.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+
+	log "github.com/tetratelabs/telemetry-gokit-log"
+)
+
+// memExporter is an sdklog.Exporter that keeps every exported Record in
+// memory, for test assertions. Note that sdklog.Record is the SDK's own
+// type, distinct from the otellog.Record the API (and NewOTLPLogger
+// internally) builds -- the accessor methods below (Body, Severity,
+// WalkAttributes, ...) return the shared otellog.Value/otellog.KeyValue
+// types either way.
+type memExporter struct {
+	records []sdklog.Record
+}
+
+func (m *memExporter) Export(_ context.Context, records []sdklog.Record) error {
+	m.records = append(m.records, records...)
+	return nil
+}
+
+func (m *memExporter) Shutdown(context.Context) error   { return nil }
+func (m *memExporter) ForceFlush(context.Context) error { return nil }
+
+func attr(t *testing.T, rec sdklog.Record, key string) (otellog.Value, bool) {
+	t.Helper()
+	var (
+		val   otellog.Value
+		found bool
+	)
+	rec.WalkAttributes(func(kv otellog.KeyValue) bool {
+		if kv.Key == key {
+			val, found = kv.Value, true
+			return false
+		}
+		return true
+	})
+	return val, found
+}
+
+func TestOTLPLogger(t *testing.T) {
+	exp := &memExporter{}
+	logger := log.NewOTLPLogger(exp)
+
+	logger.Error("boom", errors.New("bang"), "a", 1)
+
+	if want, have := 1, len(exp.records); want != have {
+		t.Fatalf("want %d records, have %d", want, have)
+	}
+	rec := exp.records[0]
+	if want, have := otellog.SeverityError, rec.Severity(); want != have {
+		t.Errorf("want %v, have %v", want, have)
+	}
+	if want, have := "boom", rec.Body().AsString(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+	if v, ok := attr(t, rec, "exception.message"); !ok || v.AsString() != "bang" {
+		t.Errorf("want exception.message=bang, have %v (found=%v)", v, ok)
+	}
+	if v, ok := attr(t, rec, "a"); !ok || v.AsInt64() != 1 {
+		t.Errorf("want a=1, have %v (found=%v)", v, ok)
+	}
+}
+
+func TestOTLPLoggerSuppressesBelowLevel(t *testing.T) {
+	exp := &memExporter{}
+	logger := log.NewOTLPLogger(exp)
+
+	logger.Debug("hello")
+	if want, have := 0, len(exp.records); want != have {
+		t.Fatalf("want %d records, have %d", want, have)
+	}
+}
+
+func TestOTLPLoggerTraceCorrelation(t *testing.T) {
+	exp := &memExporter{}
+	logger := log.NewOTLPLogger(exp)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    [16]byte{1},
+		SpanID:     [8]byte{1},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	logger.Context(ctx).Info("hello")
+
+	if want, have := 1, len(exp.records); want != have {
+		t.Fatalf("want %d records, have %d", want, have)
+	}
+	if v, ok := attr(t, exp.records[0], "trace_id"); !ok || v.AsString() != sc.TraceID().String() {
+		t.Errorf("want trace_id=%s, have %v (found=%v)", sc.TraceID(), v, ok)
+	}
+}
@@ -16,6 +16,8 @@ package logger
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"strings"
 	"sync"
@@ -40,6 +42,7 @@ type scope struct {
 	name        string
 	description string
 	logger      *Logger
+	opts        []FilterOption
 }
 
 // NewManager returns a new Scope Manager for Logger.
@@ -50,8 +53,11 @@ func NewManager(logger *Logger) *Manager {
 	}
 }
 
-// RegisterScope takes a name and description and returns a scoped Logger.
-func (s *Manager) RegisterScope(name, description string) *Logger {
+// RegisterScope takes a name and description and returns a scoped Logger. If
+// one or more FilterOptions are provided, the scoped Logger is wrapped with
+// NewFilter, allowing this scope's verbosity to be tuned per log-line key in
+// addition to its own output level.
+func (s *Manager) RegisterScope(name, description string, opts ...FilterOption) *Logger {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
@@ -62,10 +68,14 @@ func (s *Manager) RegisterScope(name, description string) *Logger {
 	}
 	newLogger := New(s.logger.UnwrapLogger())
 	newLogger.SetLevel(s.logger.Level())
+	if len(opts) > 0 {
+		newLogger = NewFilter(newLogger, opts...)
+	}
 	scoped = &scope{
 		name:        name,
 		description: description,
 		logger:      newLogger,
+		opts:        opts,
 	}
 	s.registry[name] = scoped
 
@@ -101,48 +111,86 @@ func (s *Manager) Scopes() []string {
 
 // SetDefaultOutputLevel sets the minimum log output level for all scopes.
 func (s *Manager) SetDefaultOutputLevel(lvl Level) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
 	// update base logger
 	s.logger.SetLevel(lvl)
 	// update all scoped loggers
 	for _, sg := range s.registry {
-		sg.logger.SetLevel(lvl)
+		targetLevel(sg.logger).SetLevel(lvl)
 	}
 }
 
 // SetScopeOutputLevel sets the minimum log output level for a given scope.
 func (s *Manager) SetScopeOutputLevel(name string, lvl Level) error {
 	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
 	name = strings.ToLower(strings.Trim(name, "\r\n\t "))
 	sc, has := s.registry[name]
-	s.mtx.Unlock()
 	if !has {
 		return fmt.Errorf("scope %q not found", name)
 	}
 
-	sc.logger.SetLevel(lvl)
+	targetLevel(sc.logger).SetLevel(lvl)
 	return nil
 }
 
+// SetOutputFormat changes the wire format of the default logger and of every
+// registered scope, rebuilding each scope's logger -- including any filter
+// wrapping installed through RegisterScope's FilterOptions -- around the new
+// format, while preserving each scope's currently configured output level.
+func (s *Manager) SetOutputFormat(format LogFormat, w io.Writer) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.logger = MustNewDefaultLogger(format, s.logger.Level(), w)
+	for _, sg := range s.registry {
+		lvl := targetLevel(sg.logger).Level()
+		newLogger := New(s.logger.UnwrapLogger())
+		newLogger.SetLevel(s.logger.Level())
+		if len(sg.opts) > 0 {
+			newLogger = NewFilter(newLogger, sg.opts...)
+		}
+		targetLevel(newLogger).SetLevel(lvl)
+		sg.logger = newLogger
+	}
+}
+
 // GetDefaultOutputLevel returns the default minimum output level for scopes.
 func (s *Manager) GetDefaultOutputLevel() Level {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
 	return s.logger.Level()
 }
 
 // GetOutputLevel returns the minimum log output level for a given scope.
 func (s *Manager) GetOutputLevel(name string) (Level, error) {
 	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
 	name = strings.ToLower(strings.Trim(name, "\r\n\t "))
 	sc, has := s.registry[name]
-	s.mtx.Unlock()
 	if !has {
 		return None, fmt.Errorf("scope %q not found", name)
 	}
-	return sc.logger.Level(), nil
+	return targetLevel(sc.logger).Level(), nil
 }
 
 // PrintRegisteredScopes logs all the registered scopes and their configured
 // output levels.
 func (s *Manager) PrintRegisteredScopes() {
+	_ = s.writeRegisteredScopes(os.Stdout)
+}
+
+// writeRegisteredScopes writes the registered scopes and their configured
+// output levels to w, in the same format as PrintRegisteredScopes.
+func (s *Manager) writeRegisteredScopes(w io.Writer) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
 	pad := 7
 
 	names := make([]string, 0, len(s.registry))
@@ -154,20 +202,27 @@ func (s *Manager) PrintRegisteredScopes() {
 	}
 	sort.Strings(names)
 
-	fmt.Println("registered logging scopes:")
-	fmt.Printf("- %-*s [%-5s]  %s\n",
+	if _, err := fmt.Fprintln(w, "registered logging scopes:"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "- %-*s [%-5s]  %s\n",
 		pad,
 		"default",
 		levelToString[s.logger.Level()],
 		"",
-	)
+	); err != nil {
+		return err
+	}
 	for _, n := range names {
 		sc := s.registry[n]
-		fmt.Printf("- %-*s [%-5s]  %s\n",
+		if _, err := fmt.Fprintf(w, "- %-*s [%-5s]  %s\n",
 			pad,
 			sc.name,
-			levelToString[sc.logger.Level()],
+			levelToString[targetLevel(sc.logger).Level()],
 			sc.description,
-		)
+		); err != nil {
+			return err
+		}
 	}
+	return nil
 }
@@ -0,0 +1,143 @@
+// Copyright (c) Tetrate, Inc 2021.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-kit/log"
+)
+
+// NewTestLogger returns a telemetry.Logger implementation that writes each
+// log line through tb.Log, so lines appear in `go test -v` output attributed
+// to the (sub)test that produced them, instead of being interleaved on
+// stdout. It formats identically to NewSyncLogfmt, so output can be diffed
+// the same way, and honors SetLevel like any other Logger.
+//
+// Once tb's test has completed, further log lines are silently dropped
+// rather than calling tb.Log, which panics if invoked for a finished test --
+// this guards against goroutines started by the test still logging after it
+// returns.
+func NewTestLogger(tb testing.TB) *Logger {
+	a := &testAdapter{tb: tb}
+	a.enc = log.NewLogfmtLogger(&a.buf)
+	tb.Cleanup(func() {
+		a.mu.Lock()
+		a.done = true
+		a.mu.Unlock()
+	})
+	return New(a)
+}
+
+type testAdapter struct {
+	tb testing.TB
+
+	// mu guards done. Log holds a read lock across its whole check-and-log
+	// sequence, so it can never observe done as false and then lose a race
+	// with Cleanup calling tb.Log after the test has already completed.
+	mu   sync.RWMutex
+	done bool
+
+	bufMtx sync.Mutex
+	buf    bytes.Buffer
+	enc    log.Logger
+}
+
+// Log implements log.Logger.
+func (a *testAdapter) Log(keyvals ...interface{}) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.done {
+		return nil
+	}
+
+	a.bufMtx.Lock()
+	a.buf.Reset()
+	err := a.enc.Log(keyvals...)
+	line := strings.TrimSuffix(a.buf.String(), "\n")
+	a.bufMtx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	a.tb.Helper()
+	a.tb.Log(line)
+	return nil
+}
+
+// Record is a single structured log line captured by a Buffer.
+type Record struct {
+	// KeyValues holds the full, ordered key-value pairs Logger produced for
+	// this line, including "msg", "level" and, for Error, "error".
+	KeyValues []interface{}
+}
+
+// Get returns the value associated with key in this Record, and whether key
+// was present at all.
+func (r Record) Get(key string) (interface{}, bool) {
+	for i := 0; i+1 < len(r.KeyValues); i += 2 {
+		if k, ok := r.KeyValues[i].(string); ok && k == key {
+			return r.KeyValues[i+1], true
+		}
+	}
+	return nil, false
+}
+
+// Buffer captures structured Records from a Logger created by
+// NewBufferedLogger, so tests can assert on individual keys/values directly
+// instead of hand-comparing formatted log lines.
+type Buffer struct {
+	mtx     sync.Mutex
+	records []Record
+}
+
+// Log implements log.Logger.
+func (b *Buffer) Log(keyvals ...interface{}) error {
+	kv := make([]interface{}, len(keyvals))
+	copy(kv, keyvals)
+
+	b.mtx.Lock()
+	b.records = append(b.records, Record{KeyValues: kv})
+	b.mtx.Unlock()
+	return nil
+}
+
+// Records returns a copy of every Record captured so far.
+func (b *Buffer) Records() []Record {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	out := make([]Record, len(b.records))
+	copy(out, b.records)
+	return out
+}
+
+// Reset discards every Record captured so far.
+func (b *Buffer) Reset() {
+	b.mtx.Lock()
+	b.records = nil
+	b.mtx.Unlock()
+}
+
+// NewBufferedLogger returns a telemetry.Logger implementation that captures
+// each log line as a structured Record instead of rendering it, so tests can
+// assert on individual keys/values without string matching.
+func NewBufferedLogger() (*Logger, *Buffer) {
+	buf := &Buffer{}
+	return New(buf), buf
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Tetrate, Inc 2021.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger_test
+
+import (
+	"errors"
+	"testing"
+
+	log "github.com/tetratelabs/telemetry-gokit-log"
+	"github.com/tetratelabs/telemetry/level"
+)
+
+// testing.TB deliberately cannot be implemented outside the testing
+// package, so this only exercises NewTestLogger end-to-end against the real
+// *testing.T; there is no way to assert on the rendered line from here.
+func TestNewTestLogger(t *testing.T) {
+	logger := log.NewTestLogger(t)
+	logger.Info("hello")
+
+	logger.SetLevel(level.Error)
+	logger.Debug("suppressed, should not appear above")
+}
+
+func TestNewBufferedLogger(t *testing.T) {
+	logger, buf := log.NewBufferedLogger()
+	logger.With("scope", "p2p").Error("boom", errors.New("bang"), "a", 1)
+
+	records := buf.Records()
+	if want, have := 1, len(records); want != have {
+		t.Fatalf("want %d records, have %d", want, have)
+	}
+
+	rec := records[0]
+	if v, ok := rec.Get("msg"); !ok || v != "boom" {
+		t.Errorf("want msg=boom, have %v (found=%v)", v, ok)
+	}
+	if v, ok := rec.Get("level"); !ok || v != "error" {
+		t.Errorf("want level=error, have %v (found=%v)", v, ok)
+	}
+	v, ok := rec.Get("error")
+	if !ok {
+		t.Fatal("want error key present")
+	}
+	if err, ok := v.(error); !ok || err.Error() != "bang" {
+		t.Errorf("want error=bang, have %v", v)
+	}
+	if v, ok := rec.Get("scope"); !ok || v != "p2p" {
+		t.Errorf("want scope=p2p, have %v (found=%v)", v, ok)
+	}
+	if v, ok := rec.Get("a"); !ok || v != 1 {
+		t.Errorf("want a=1, have %v (found=%v)", v, ok)
+	}
+	if _, ok := rec.Get("missing"); ok {
+		t.Error("want ok=false for a key that was never logged")
+	}
+
+	buf.Reset()
+	if want, have := 0, len(buf.Records()); want != have {
+		t.Fatalf("want %d records after Reset, have %d", want, have)
+	}
+}
+
+func TestNewBufferedLoggerRespectsLevel(t *testing.T) {
+	logger, buf := log.NewBufferedLogger()
+	logger.Debug("suppressed")
+	if want, have := 0, len(buf.Records()); want != have {
+		t.Fatalf("want %d records, have %d", want, have)
+	}
+
+	logger.SetLevel(level.Debug)
+	logger.Debug("allowed")
+	if want, have := 1, len(buf.Records()); want != have {
+		t.Fatalf("want %d records, have %d", want, have)
+	}
+}
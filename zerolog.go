@@ -0,0 +1,112 @@
+// Copyright (c) Tetrate, Inc 2021.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"io"
+
+	"github.com/rs/zerolog"
+)
+
+// NewZerolog returns a telemetry.Logger implementation that renders through
+// zerolog's Event pool and typed field setters instead of Go kit's
+// reflect-based logfmt encoder. Like every Logger, a suppressed Debug call
+// short-circuits on the atomic.LoadInt32 level check in the shared
+// Logger.Debug wrapper before it builds its keyvals slice or reaches the
+// backend, so that path costs the same here as with NewSyncLogfmt. On a
+// realized (non-suppressed) Info/Error call, most of the
+// allocation cost comes from the shared Logger.Info/Error wrapper building
+// its keyvals slice -- identical regardless of backend -- so don't expect
+// more than a modest reduction there; see BenchmarkZerolog*/
+// BenchmarkSyncLogfmtInfo. It is still the same Logger type, so the
+// Manager/group scope configuration users already depend on keeps working
+// unchanged; only the rendering underneath differs.
+func NewZerolog(w io.Writer) *Logger {
+	return New(&zerologAdapter{logger: zerolog.New(w)})
+}
+
+// zerologAdapter bridges Go kit's log.Logger interface to a zerolog.Logger.
+type zerologAdapter struct {
+	logger zerolog.Logger
+}
+
+// Log implements log.Logger. The "level" key-value pair selects the
+// zerolog.Event (and, through it, zerolog's own "level" field); every other
+// pair is added to the event in the order Logger built it, matching the
+// insertion-order rendering of NewSyncLogfmt.
+func (a *zerologAdapter) Log(keyvals ...interface{}) error {
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, "(MISSING)")
+	}
+
+	lvl := zerolog.InfoLevel
+	for i := 0; i < len(keyvals); i += 2 {
+		if keyvals[i] != "level" {
+			continue
+		}
+		if s, ok := keyvals[i+1].(string); ok {
+			lvl = zerologLevel(s)
+		}
+		break
+	}
+
+	evt := a.logger.WithLevel(lvl)
+	for i := 0; i < len(keyvals); i += 2 {
+		k, ok := keyvals[i].(string)
+		if !ok || k == "level" {
+			continue
+		}
+		evt = zerologField(evt, k, keyvals[i+1])
+	}
+	evt.Send()
+
+	return nil
+}
+
+// zerologLevel maps the level string Logger attaches to each log line to
+// the equivalent zerolog.Level.
+func zerologLevel(s string) zerolog.Level {
+	switch s {
+	case "debug":
+		return zerolog.DebugLevel
+	case "error":
+		return zerolog.ErrorLevel
+	case "none":
+		return zerolog.Disabled
+	default:
+		return zerolog.InfoLevel
+	}
+}
+
+// zerologField adds key/value to evt using zerolog's typed setters where
+// possible, to avoid the reflection-based fallback.
+func zerologField(evt *zerolog.Event, key string, value interface{}) *zerolog.Event {
+	switch v := value.(type) {
+	case string:
+		return evt.Str(key, v)
+	case error:
+		return evt.AnErr(key, v)
+	case bool:
+		return evt.Bool(key, v)
+	case int:
+		return evt.Int(key, v)
+	case int64:
+		return evt.Int64(key, v)
+	case float64:
+		return evt.Float64(key, v)
+	default:
+		return evt.Interface(key, v)
+	}
+}